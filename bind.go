@@ -0,0 +1,261 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// configTag is the parsed form of a `config:"keyname,default=...,required"`
+// struct tag.
+type configTag struct {
+	name     string
+	def      string
+	hasDef   bool
+	required bool
+}
+
+func parseConfigTag(field reflect.StructField) configTag {
+	var t configTag
+	raw, ok := field.Tag.Lookup("config")
+	if !ok {
+		return t
+	}
+	parts := strings.Split(raw, ",")
+	t.name = parts[0]
+	for _, p := range parts[1:] {
+		switch {
+		case p == "required":
+			t.required = true
+		case strings.HasPrefix(p, "default="):
+			t.def, t.hasDef = strings.TrimPrefix(p, "default="), true
+		}
+	}
+	return t
+}
+
+// Unmarshal walks dst (a pointer to a struct) via reflection and populates
+// its fields from config keys named by `config:"keyname"` struct tags,
+// falling back to the field name when the tag is absent. Nested structs
+// are addressed with dotted keys (a "db" struct with an "host" field reads
+// "db.host"). The "default=..." tag option supplies a value to parse when
+// the key is missing, and "required" makes a missing key (with no
+// default) an error.
+//
+// Value conversion reuses convertValue, the same logic backing
+// Key[T].Get, so behavior is consistent between the two APIs.
+func (c *Config) Unmarshal(dst interface{}) error {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("config: Unmarshal: dst must be a pointer to a struct")
+	}
+	return c.unmarshalStruct(rv.Elem(), "")
+}
+
+func (c *Config) unmarshalStruct(sv reflect.Value, prefix string) error {
+	st := sv.Type()
+	for i := 0; i < st.NumField(); i++ {
+		field := st.Field(i)
+		if field.PkgPath != "" { // unexported
+			continue
+		}
+		tag := parseConfigTag(field)
+		if tag.name == "-" {
+			continue
+		}
+		key := tag.name
+		if key == "" {
+			key = field.Name
+		}
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		fv := sv.Field(i)
+
+		if fv.Kind() == reflect.Struct && fv.Type() != timeType {
+			if err := c.unmarshalStruct(fv, key); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := c.unmarshalField(fv, key, tag); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Config) unmarshalField(fv reflect.Value, key string, tag configTag) error {
+	v, ok := c.Get(key)
+	if !ok {
+		switch {
+		case tag.hasDef:
+			return setFieldFromString(fv, tag.def)
+		case tag.required:
+			return fmt.Errorf("config: Unmarshal: required key %q not found", key)
+		default:
+			return nil
+		}
+	}
+
+	switch fv.Type() {
+	case timeType:
+		s, ok := v.(string)
+		if !ok {
+			return fmt.Errorf("config: Unmarshal: key %q: expected string for time.Time, got %T", key, v)
+		}
+		var t time.Time
+		if err := t.UnmarshalText([]byte(s)); err != nil {
+			return fmt.Errorf("config: Unmarshal: key %q: %w", key, err)
+		}
+		fv.Set(reflect.ValueOf(t))
+		return nil
+	case durationType:
+		switch v := v.(type) {
+		case string:
+			d, err := time.ParseDuration(v)
+			if err != nil {
+				return fmt.Errorf("config: Unmarshal: key %q: %w", key, err)
+			}
+			fv.Set(reflect.ValueOf(d))
+			return nil
+		case float64:
+			fv.Set(reflect.ValueOf(time.Duration(v)))
+			return nil
+		}
+	}
+
+	if fv.Kind() == reflect.Slice {
+		return unmarshalSlice(fv, key, v)
+	}
+
+	rv, ok := convertValue(v, fv.Type())
+	if !ok {
+		return fmt.Errorf("config: Unmarshal: key %q: failed to cast value (wanted type: %s but got type: %T)", key, fv.Type(), v)
+	}
+	fv.Set(rv)
+	return nil
+}
+
+func unmarshalSlice(fv reflect.Value, key string, v interface{}) error {
+	items, ok := v.([]interface{})
+	if !ok {
+		return fmt.Errorf("config: Unmarshal: key %q: expected a slice, got %T", key, v)
+	}
+	elemType := fv.Type().Elem()
+	out := reflect.MakeSlice(fv.Type(), len(items), len(items))
+	for i, item := range items {
+		rv, ok := convertValue(item, elemType)
+		if !ok {
+			return fmt.Errorf("config: Unmarshal: key %q[%d]: failed to cast value (wanted type: %s but got type: %T)", key, i, elemType, item)
+		}
+		out.Index(i).Set(rv)
+	}
+	fv.Set(out)
+	return nil
+}
+
+// setFieldFromString parses a tag's default= string into fv's type.
+func setFieldFromString(fv reflect.Value, s string) error {
+	switch fv.Type() {
+	case timeType:
+		var t time.Time
+		if err := t.UnmarshalText([]byte(s)); err != nil {
+			return err
+		}
+		fv.Set(reflect.ValueOf(t))
+		return nil
+	case durationType:
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+		fv.Set(reflect.ValueOf(d))
+		return nil
+	}
+	switch fv.Kind() {
+	case reflect.String:
+		fv.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		fv.SetBool(b)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return err
+		}
+		fv.SetFloat(f)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return err
+		}
+		fv.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, 64)
+		if err != nil {
+			return err
+		}
+		fv.SetUint(n)
+	default:
+		return fmt.Errorf("config: Unmarshal: unsupported default for kind %s", fv.Kind())
+	}
+	return nil
+}
+
+// Marshal walks src (a struct or pointer to a struct) via reflection and
+// writes each field back into c through Put, using the same
+// `config:"keyname"` tags as Unmarshal.
+func (c *Config) Marshal(src interface{}) error {
+	rv := reflect.ValueOf(src)
+	if rv.Kind() == reflect.Ptr {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return fmt.Errorf("config: Marshal: src must be a struct or pointer to a struct")
+	}
+	c.marshalStruct(rv, "")
+	return nil
+}
+
+func (c *Config) marshalStruct(sv reflect.Value, prefix string) {
+	st := sv.Type()
+	for i := 0; i < st.NumField(); i++ {
+		field := st.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		tag := parseConfigTag(field)
+		if tag.name == "-" {
+			continue
+		}
+		key := tag.name
+		if key == "" {
+			key = field.Name
+		}
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		fv := sv.Field(i)
+
+		if fv.Kind() == reflect.Struct && fv.Type() != timeType {
+			c.marshalStruct(fv, key)
+			continue
+		}
+		if fv.Type() == timeType {
+			t := fv.Interface().(time.Time)
+			b, _ := t.MarshalText()
+			c.Put(key, string(b))
+			continue
+		}
+		c.Put(key, fv.Interface())
+	}
+}
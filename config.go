@@ -16,6 +16,8 @@ type ConfigType int
 const (
 	Json ConfigType = iota
 	Line
+	Yaml
+	Toml
 )
 
 // Keeps config in-memory reopening and closing for each I/O operation
@@ -27,6 +29,13 @@ type Config struct {
 	mu       sync.RWMutex
 
 	Type ConfigType
+
+	sources []Source
+
+	watchMu  sync.Mutex
+	watchers map[string][]chan Event
+
+	envBindings map[string]string
 }
 
 // Maybe ?: In the case that a value is present in both default map and in file
@@ -41,35 +50,29 @@ type Config struct {
 // Line config only supports values with kind float64, strings, and booleans.
 // ints; units; and float32 are saved and decoded as float64
 //
+// Yaml and Toml configs round-trip through the same map[string]interface{}
+// shape as Json and additionally support nested maps and arrays, which the
+// Line parser cannot represent.
+//
 // Warning: LoadConfigFile only does shallow copies of values in default (take care about race conditions)
+//
+// LoadConfigFile is a thin wrapper around NewConfig that registers a
+// single FileSource; use NewConfig directly to layer a file with other
+// sources (env vars, flags, ...).
 func LoadConfigFile(filepath string, configType ConfigType) (*Config, error) {
-	var config = &Config{
-		Config:   map[string]interface{}{},
-		Filepath: filepath,
-		mu:       sync.RWMutex{},
-		Type:     configType,
-	}
-	f, err := os.Open(filepath)
-	if err != nil {
-		if os.IsNotExist(err) {
-			return config, nil
-		}
-		return nil, err
-	}
-	defer f.Close()
-	switch configType {
-	case Json:
-		config.Config, err = parseFileLine(f)
-	case Line:
-		config.Config, err = parseFileLine(f)
-	default:
-		config.Type = Json
-		config.Config, err = parseFileJSON(f)
-	}
-	return config, err
+	return NewConfig(&FileSource{Filepath: filepath, Type: configType})
 }
 
 func (c *Config) Get(key string) (interface{}, bool) {
+	c.mu.RLock()
+	envVar, bound := c.envBindings[key]
+	c.mu.RUnlock()
+	if bound {
+		if raw, ok := os.LookupEnv(envVar); ok {
+			return convertScalar(raw), true
+		}
+	}
+
 	c.mu.RLock()
 	v, ok := c.Config[key]
 	c.mu.RUnlock()
@@ -78,8 +81,15 @@ func (c *Config) Get(key string) (interface{}, bool) {
 
 func (c *Config) Put(key string, val interface{}) {
 	c.mu.Lock()
+	old, hadOld := c.Config[key]
 	c.Config[key] = val
 	c.mu.Unlock()
+	if !hadOld {
+		old = nil
+	}
+	if !hadOld || !reflect.DeepEqual(old, val) {
+		c.notify(key, old, val, "put")
+	}
 }
 
 // SyncWithDefaults will use the map to make up for all value present in default but not in file.
@@ -106,26 +116,6 @@ func (c *Config) GetCopyOfConfig() map[string]interface{} {
 	return m
 }
 
-func (c *Config) SaveFile() error {
-	f, err := os.Create(c.Filepath)
-	if err != nil {
-		return err
-	}
-	defer f.Close()
-	c.mu.Lock()
-	defer c.mu.Unlock()
-	switch c.Type {
-	case Json:
-		err = writeFileLine(f, c.Config)
-	case Line:
-		err = writeFileLine(f, c.Config)
-	default:
-		c.Type = Json
-		err = writeFileJSON(f, c.Config)
-	}
-	return err
-}
-
 type Getable interface {
 	~string | ~float64 | []interface{} | map[string]interface{}
 }
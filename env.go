@@ -0,0 +1,53 @@
+package config
+
+import (
+	"os"
+	"strconv"
+	"strings"
+)
+
+// convertScalar parses s as float64 or bool when possible, mirroring
+// parseFileLine's conversion rules (plus booleans), and otherwise returns
+// it unchanged as a string.
+func convertScalar(s string) interface{} {
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		return f
+	}
+	if b, err := strconv.ParseBool(s); err == nil {
+		return b
+	}
+	return s
+}
+
+// OverlayEnv scans os.Environ() and, for every variable whose name starts
+// with prefix, derives a config key by stripping the prefix, lower-casing
+// the remainder, and turning "__" into "." for nested keys (so
+// MYAPP_DB__HOST becomes "db.host" under prefix "MYAPP_"), then Puts it.
+// Values are parsed as float64 or bool when possible and stored as a plain
+// string otherwise. This supports 12-factor style deployments where
+// operators tune config through the environment without editing files.
+func (c *Config) OverlayEnv(prefix string) {
+	for _, kv := range os.Environ() {
+		sp := strings.SplitN(kv, "=", 2)
+		if len(sp) != 2 || !strings.HasPrefix(sp[0], prefix) {
+			continue
+		}
+		key := strings.ToLower(strings.TrimPrefix(sp[0], prefix))
+		key = strings.ReplaceAll(key, "__", ".")
+		c.Put(key, convertScalar(sp[1]))
+	}
+}
+
+// BindEnv binds key to the environment variable envVar: as long as envVar
+// is set, Get(key) (and therefore Key[T].Get) returns its value instead of
+// whatever is stored in Config, taking precedence over both file-loaded
+// and OverlayEnv-derived values. Unlike OverlayEnv, BindEnv is a one-off,
+// explicit mapping and does not touch the underlying Config map.
+func (c *Config) BindEnv(key, envVar string) {
+	c.mu.Lock()
+	if c.envBindings == nil {
+		c.envBindings = map[string]string{}
+	}
+	c.envBindings[key] = envVar
+	c.mu.Unlock()
+}
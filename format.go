@@ -0,0 +1,92 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// DetectTypeFromExtension picks a ConfigType from a file's extension
+// (.yaml/.yml, .toml, .json, .conf/anything else falls back to Line), so
+// callers don't have to hardcode the format alongside the path.
+func DetectTypeFromExtension(path string) ConfigType {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return Yaml
+	case ".toml":
+		return Toml
+	case ".json":
+		return Json
+	default:
+		return Line
+	}
+}
+
+// YAML and TOML round-trip through the same map[string]interface{} shape
+// as JSON, so Key[T].Get keeps working unchanged regardless of format.
+// Unlike Line, both support nested maps and arrays.
+
+func parseFileYAML(f *os.File) (map[string]interface{}, error) {
+	var m = map[string]interface{}{}
+	e := yaml.NewDecoder(f).Decode(&m)
+	if e != nil {
+		return m, e
+	}
+	return normalizeYAML(m), nil
+}
+
+func parseFileTOML(f *os.File) (map[string]interface{}, error) {
+	var m = map[string]interface{}{}
+	_, e := toml.NewDecoder(f).Decode(&m)
+	return m, e
+}
+
+func writeFileYAML(f *os.File, m map[string]interface{}) error {
+	return yaml.NewEncoder(f).Encode(m)
+}
+
+func writeFileTOML(f *os.File, m map[string]interface{}) error {
+	return toml.NewEncoder(f).Encode(m)
+}
+
+// normalizeYAML recursively converts the map[string]interface{} (and
+// nested map[interface{}]interface{}) that yaml.v3 can produce for
+// non-string keys into plain map[string]interface{}, matching the shape
+// produced by parseFileJSON/parseFileTOML.
+func normalizeYAML(v interface{}) map[string]interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for k, vv := range v {
+			out[k] = normalizeYAMLValue(vv)
+		}
+		return out
+	default:
+		return map[string]interface{}{}
+	}
+}
+
+func normalizeYAMLValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		return normalizeYAML(v)
+	case map[interface{}]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for k, vv := range v {
+			out[fmt.Sprint(k)] = normalizeYAMLValue(vv)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, vv := range v {
+			out[i] = normalizeYAMLValue(vv)
+		}
+		return out
+	default:
+		return v
+	}
+}
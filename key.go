@@ -28,6 +28,34 @@ type TimeKey struct {
 	Default time.Time
 } // will return zero value if key is not present or if is not parsable
 
+// convertValue attempts to convert v into the target type: an exact type
+// match is returned as-is, bool and string are special-cased to parse into
+// each other, and everything else falls back to reflect.Value.Convert().
+// This is the single conversion path shared by Key[T].Get/GetErr and
+// Config.Unmarshal.
+func convertValue(v interface{}, target reflect.Type) (reflect.Value, bool) {
+	rv := reflect.ValueOf(v)
+	if rv.IsValid() && rv.Type() == target {
+		return rv, true
+	}
+	switch v := v.(type) {
+	case bool:
+		if target.Kind() == reflect.String {
+			return reflect.ValueOf(strconv.FormatBool(v)).Convert(target), true
+		}
+	case string:
+		if target.Kind() == reflect.Bool {
+			if b, err := strconv.ParseBool(v); err == nil {
+				return reflect.ValueOf(b).Convert(target), true
+			}
+		}
+	}
+	if rv.IsValid() && rv.CanConvert(target) { // does not support string to bool and vice versa
+		return rv.Convert(target), true
+	}
+	return reflect.Value{}, false
+}
+
 // if key is not present in Config or cannot be converted into T, Get() return the zero value of T.
 //
 // conversions are made by reflect.Value.Convert(), and as a special case booleans and strings are
@@ -38,26 +66,11 @@ func (k Key[T]) Get(c *Config) T {
 	if !ok {
 		return k.Default
 	}
-	switch v := v.(type) {
-	case T:
-		return v
-	case bool:
-		if reflect.ValueOf(ret).Kind() == reflect.String {
-			return interface{}(strconv.FormatBool(v)).(T)
-		}
-	case string:
-		if reflect.ValueOf(ret).Kind() == reflect.Bool {
-			b, err := strconv.ParseBool(v)
-			if err == nil {
-				return interface{}(b).(T)
-			}
-		}
-	}
-	rv := reflect.ValueOf(v)
-	if rv.CanConvert(reflect.TypeOf(ret)) { // does not support string to bool and vice versa
-		return rv.Convert(reflect.TypeOf(ret)).Interface().(T)
+	rv, ok := convertValue(v, reflect.TypeOf(ret))
+	if !ok {
+		return k.Default
 	}
-	return k.Default
+	return rv.Interface().(T)
 }
 
 // ignores default value and returns an error if it fails to find or cast loaded value
@@ -67,32 +80,23 @@ func (k Key[T]) GetErr(c *Config) (T, error) {
 	if !ok {
 		return ret, ErrKeyNotFound
 	}
-	switch v := v.(type) {
-	case T:
-		return v, nil
-	case bool:
-		if reflect.ValueOf(ret).Kind() == reflect.String {
-			return interface{}(strconv.FormatBool(v)).(T), nil
-		}
-	case string:
-		if reflect.ValueOf(ret).Kind() == reflect.Bool {
-			b, err := strconv.ParseBool(v)
-			if err == nil {
-				return interface{}(b).(T), nil
-			}
-		}
-	}
-	rv := reflect.ValueOf(v)
-	if rv.CanConvert(reflect.TypeOf(ret)) {
-		return rv.Convert(reflect.TypeOf(ret)).Interface().(T), nil
+	rv, ok := convertValue(v, reflect.TypeOf(ret))
+	if !ok {
+		return ret, fmt.Errorf("config file Get: failed to cast value (wanted type: %T but got type: %T)", ret, v)
 	}
-	return ret, fmt.Errorf("config file Get: failed to cast value (wanted type: %T but got type: %T)", ret, v)
+	return rv.Interface().(T), nil
 }
 
 func (k Key[T]) Put(c *Config, v T) {
 	c.Put(k.Key, v)
 }
 
+// Watch subscribes to changes on this key; it is equivalent to
+// c.Watch(k.Key). See Config.Watch for delivery semantics.
+func (k Key[T]) Watch(c *Config) (<-chan Event, func()) {
+	return c.Watch(k.Key)
+}
+
 // checks if a valid (castable) value is present in config, if not, default will be added
 func (k Key[T]) Sync(c *Config) {
 	_, err := k.GetErr(c)
@@ -0,0 +1,51 @@
+//go:build windows
+
+package config
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+const lockfileExclusiveLock = 0x2
+
+var (
+	modkernel32      = syscall.NewLazyDLL("kernel32.dll")
+	procLockFileEx   = modkernel32.NewProc("LockFileEx")
+	procUnlockFileEx = modkernel32.NewProc("UnlockFileEx")
+)
+
+// lockFile opens (creating if needed) and LockFileEx-locks path
+// exclusively, returning a func that unlocks and closes it.
+func lockFile(path string) (unlock func() error, err error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	var overlapped syscall.Overlapped
+	r1, _, e1 := procLockFileEx.Call(
+		f.Fd(),
+		uintptr(lockfileExclusiveLock),
+		0,
+		^uintptr(0),
+		^uintptr(0),
+		uintptr(unsafe.Pointer(&overlapped)),
+	)
+	if r1 == 0 {
+		f.Close()
+		return nil, e1
+	}
+
+	return func() error {
+		procUnlockFileEx.Call(
+			f.Fd(),
+			0,
+			^uintptr(0),
+			^uintptr(0),
+			uintptr(unsafe.Pointer(&overlapped)),
+		)
+		return f.Close()
+	}, nil
+}
@@ -0,0 +1,117 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// SaveFile serializes the config to Filepath, writing to a Filepath+".tmp"
+// sibling first, fsyncing it, then renaming it over the target. This
+// avoids leaving a truncated or corrupted file behind if the process is
+// killed mid-write, unlike writing to Filepath directly.
+//
+// SaveFile only needs a read lock: it takes a deep-copy snapshot of
+// c.Config up front and does all I/O against the copy, so it never holds
+// mu across disk writes.
+func (c *Config) SaveFile() error {
+	c.mu.RLock()
+	snapshot := deepCopyMap(c.Config)
+	typ := c.Type
+	c.mu.RUnlock()
+	return writeConfigFile(c.Filepath, typ, snapshot)
+}
+
+// SaveFileLocked behaves like SaveFile but additionally holds an OS-level
+// advisory lock (flock on unix, LockFileEx on windows) on a
+// Filepath+".lock" sidecar file for the duration of the write, so that
+// multiple processes sharing the same config file can't clobber each
+// other.
+func (c *Config) SaveFileLocked() error {
+	unlock, err := lockFile(c.Filepath + ".lock")
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return c.SaveFile()
+}
+
+// writeConfigFile performs the temp-file + fsync + rename dance shared by
+// SaveFile and SaveFileLocked.
+func writeConfigFile(path string, typ ConfigType, m map[string]interface{}) error {
+	tmp := path + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+
+	switch typ {
+	case Json:
+		err = writeFileJSON(f, m)
+	case Line:
+		err = writeFileLine(f, m)
+	case Yaml:
+		err = writeFileYAML(f, m)
+	case Toml:
+		err = writeFileTOML(f, m)
+	default:
+		err = writeFileJSON(f, m)
+	}
+	if err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return err
+	}
+	if err = f.Sync(); err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return err
+	}
+	if err = f.Close(); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	if err = os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	// Without this, the rename itself isn't guaranteed durable across a
+	// crash even though the file content is: the directory entry update
+	// could still be lost. Best-effort: some platforms (Windows) don't
+	// support fsyncing a directory handle, so a failure here is ignored.
+	fsyncDir(filepath.Dir(path))
+	return nil
+}
+
+func fsyncDir(dir string) {
+	d, err := os.Open(dir)
+	if err != nil {
+		return
+	}
+	defer d.Close()
+	d.Sync()
+}
+
+// deepCopyMap recursively copies m so callers can release a lock before
+// doing I/O on the snapshot without racing concurrent Put/Reload calls.
+func deepCopyMap(m map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		out[k] = deepCopyValue(v)
+	}
+	return out
+}
+
+func deepCopyValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		return deepCopyMap(v)
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, vv := range v {
+			out[i] = deepCopyValue(vv)
+		}
+		return out
+	default:
+		return v
+	}
+}
@@ -0,0 +1,90 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Source produces a set of configuration values from some backing store
+// (a file, the environment, an HTTP endpoint, ...). Sources are registered
+// on a Config in order and merged into its effective view, with later
+// sources overriding earlier ones on key conflicts.
+type Source interface {
+	// Load reads and returns the current values exposed by this source.
+	Load() (map[string]interface{}, error)
+	// Name identifies the source, mainly for diagnostics and Watch events.
+	Name() string
+}
+
+// NewConfig builds a Config from an ordered list of Source implementations.
+// Sources are loaded immediately and merged into the effective view; on
+// key conflicts, sources later in the list win. Use Reload to re-read
+// every source and refresh the merged view.
+func NewConfig(sources ...Source) (*Config, error) {
+	c := &Config{
+		Config:  map[string]interface{}{},
+		sources: sources,
+	}
+	if err := c.reload(); err != nil {
+		return nil, err
+	}
+	// Preserve Filepath/Type for callers relying on the single-file
+	// behavior of LoadConfigFile (e.g. SaveFile).
+	for _, s := range sources {
+		if fs, ok := s.(*FileSource); ok {
+			c.Filepath = fs.Filepath
+			c.Type = fs.Type
+			break
+		}
+	}
+	return c, nil
+}
+
+// reload re-reads every registered source and merges them, later sources
+// overriding earlier ones, then swaps the merged map in under mu and fans
+// out an Event for every key whose value diverges from the previous
+// snapshot.
+func (c *Config) reload() error {
+	merged := map[string]interface{}{}
+	origin := map[string]string{}
+	for _, s := range c.sources {
+		m, err := s.Load()
+		if err != nil {
+			return fmt.Errorf("config: source %q: %w", s.Name(), err)
+		}
+		for k, v := range m {
+			merged[k] = v
+			origin[k] = s.Name()
+		}
+	}
+
+	c.mu.Lock()
+	old := c.Config
+	c.Config = merged
+	c.mu.Unlock()
+
+	for k, v := range merged {
+		if prev, ok := old[k]; !ok || !reflect.DeepEqual(prev, v) {
+			var prevVal interface{}
+			if ok {
+				prevVal = prev
+			}
+			c.notify(k, prevVal, v, origin[k])
+		}
+	}
+	for k, prev := range old {
+		if _, ok := merged[k]; !ok {
+			c.notify(k, prev, nil, "")
+		}
+	}
+	return nil
+}
+
+// Reload re-reads every registered Source and atomically swaps the merged
+// view in place. Sources are re-applied in registration order, so later
+// sources continue to override earlier ones. Config instances created by
+// LoadConfigFile have a single FileSource registered, so Reload on them
+// simply re-reads that file.
+func (c *Config) Reload() error {
+	return c.reload()
+}
@@ -0,0 +1,149 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// FileSource loads a Json, Line, Yaml or Toml formatted file (any other
+// Type is read as Json), the same formats understood by LoadConfigFile.
+type FileSource struct {
+	Filepath string
+	Type     ConfigType
+}
+
+func (s *FileSource) Name() string { return "file:" + s.Filepath }
+
+func (s *FileSource) Load() (map[string]interface{}, error) {
+	f, err := os.Open(s.Filepath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return map[string]interface{}{}, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+	switch s.Type {
+	case Json:
+		return parseFileJSON(f)
+	case Line:
+		return parseFileLine(f)
+	case Yaml:
+		return parseFileYAML(f)
+	case Toml:
+		return parseFileTOML(f)
+	default:
+		return parseFileJSON(f)
+	}
+}
+
+// EnvSource exposes process environment variables as config values,
+// optionally restricted to a prefix which is stripped from resulting keys.
+type EnvSource struct {
+	Prefix string
+}
+
+func (s *EnvSource) Name() string { return "env:" + s.Prefix }
+
+func (s *EnvSource) Load() (map[string]interface{}, error) {
+	m := map[string]interface{}{}
+	for _, kv := range os.Environ() {
+		sp := strings.SplitN(kv, "=", 2)
+		if len(sp) != 2 {
+			continue
+		}
+		key, val := sp[0], sp[1]
+		if s.Prefix != "" {
+			if !strings.HasPrefix(key, s.Prefix) {
+				continue
+			}
+			key = strings.TrimPrefix(key, s.Prefix)
+		}
+		if f, err := strconv.ParseFloat(val, 64); err == nil {
+			m[key] = f
+			continue
+		}
+		m[key] = val
+	}
+	return m, nil
+}
+
+// CLISource exposes command-line flags of the form --key=value (or
+// -key=value) as config values.
+type CLISource struct {
+	Args []string // defaults to os.Args[1:] when nil
+}
+
+func (s *CLISource) Name() string { return "cli" }
+
+func (s *CLISource) Load() (map[string]interface{}, error) {
+	args := s.Args
+	if args == nil {
+		args = os.Args[1:]
+	}
+	m := map[string]interface{}{}
+	for _, arg := range args {
+		arg = strings.TrimPrefix(arg, "--")
+		arg = strings.TrimPrefix(arg, "-")
+		sp := strings.SplitN(arg, "=", 2)
+		if len(sp) != 2 {
+			continue
+		}
+		if f, err := strconv.ParseFloat(sp[1], 64); err == nil {
+			m[sp[0]] = f
+			continue
+		}
+		m[sp[0]] = sp[1]
+	}
+	return m, nil
+}
+
+// MapSource exposes an in-memory map[string]interface{} as a Source,
+// useful for tests or programmatically injected defaults/overrides.
+type MapSource struct {
+	Values map[string]interface{}
+}
+
+func (s *MapSource) Name() string { return "map" }
+
+func (s *MapSource) Load() (map[string]interface{}, error) {
+	m := make(map[string]interface{}, len(s.Values))
+	for k, v := range s.Values {
+		m[k] = v
+	}
+	return m, nil
+}
+
+// HTTPSource fetches a JSON object from a URL and exposes it as config
+// values. The endpoint is re-fetched on every Load, so a Config's Reload
+// picks up any change served there.
+type HTTPSource struct {
+	URL    string
+	Client *http.Client // defaults to http.DefaultClient when nil
+}
+
+func (s *HTTPSource) Name() string { return "http:" + s.URL }
+
+func (s *HTTPSource) Load() (map[string]interface{}, error) {
+	client := s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Get(s.URL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("config: http source %q: unexpected status %s", s.URL, resp.Status)
+	}
+	m := map[string]interface{}{}
+	if err := json.NewDecoder(resp.Body).Decode(&m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
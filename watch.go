@@ -0,0 +1,146 @@
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// Event describes a change observed on a watched key: the value moving
+// from Old to New (Old is nil for an added key, New is nil for a removed
+// one) and the name of the Source that produced the new value.
+type Event struct {
+	Key    string
+	Old    interface{}
+	New    interface{}
+	Source string
+}
+
+// Watch subscribes to changes on key. Events are delivered when the value
+// is added, changed, or removed, either via Put or via Reload (including
+// WatchFile-triggered reloads). The returned cancel func unsubscribes;
+// callers must call it to avoid leaking the subscription. The channel is
+// never closed (a concurrent notify could otherwise race a close and
+// panic sending on it), so callers should stop reading from it once they
+// call cancel rather than relying on a closed-channel receive to notice.
+//
+// Delivery is best-effort and coalescing, not a queue: the channel is
+// buffered to 1, and notify replaces a pending, undrained Event with the
+// newest one rather than blocking the caller of Put/Reload. A subscriber
+// that falls behind a burst of rapid changes to the same key therefore
+// only ever observes the latest value, never an intermediate one;
+// callers that need every transition should Get the key's full history
+// themselves (e.g. from a log) instead of relying on Watch.
+func (c *Config) Watch(key string) (<-chan Event, func()) {
+	ch := make(chan Event, 1)
+
+	c.watchMu.Lock()
+	if c.watchers == nil {
+		c.watchers = map[string][]chan Event{}
+	}
+	c.watchers[key] = append(c.watchers[key], ch)
+	c.watchMu.Unlock()
+
+	var once sync.Once
+	cancel := func() {
+		once.Do(func() {
+			c.watchMu.Lock()
+			subs := c.watchers[key]
+			for i, sub := range subs {
+				if sub == ch {
+					c.watchers[key] = append(subs[:i], subs[i+1:]...)
+					break
+				}
+			}
+			c.watchMu.Unlock()
+		})
+	}
+	return ch, cancel
+}
+
+// notify fans out an Event to every subscriber of key, replacing a
+// pending, undrained Event with the newest one instead of blocking the
+// caller. The subscriber list is read and the send performed under
+// watchMu so a concurrent cancel can't close (or otherwise race) a
+// channel notify is about to send on.
+func (c *Config) notify(key string, old, new interface{}, source string) {
+	c.watchMu.Lock()
+	defer c.watchMu.Unlock()
+	subs := c.watchers[key]
+	if len(subs) == 0 {
+		return
+	}
+	ev := Event{Key: key, Old: old, New: new, Source: source}
+	for _, ch := range subs {
+		select {
+		case ch <- ev:
+		default:
+			select {
+			case <-ch:
+			default:
+			}
+			select {
+			case ch <- ev:
+			default:
+			}
+		}
+	}
+}
+
+// WatchFile starts an fsnotify watcher on Filepath's parent directory and
+// calls Reload (which diffs the merged view and fans out through Watch)
+// whenever Filepath itself changes on disk. The returned cancel func
+// stops the watcher.
+//
+// The directory, rather than the file, is watched: SaveFile (and editors
+// that do the same) replace Filepath via a temp-file-then-rename, which
+// deletes the original inode. A watch on the file itself does not survive
+// that swap; a watch on the directory does, and is filtered down to
+// events for Filepath.
+func (c *Config) WatchFile() (cancel func(), err error) {
+	if c.Filepath == "" {
+		return nil, fmt.Errorf("config: WatchFile: no Filepath set")
+	}
+	target := filepath.Clean(c.Filepath)
+	dir := filepath.Dir(target)
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	if err := w.Add(dir); err != nil {
+		w.Close()
+		return nil, err
+	}
+
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case ev, ok := <-w.Events:
+				if !ok {
+					return
+				}
+				if filepath.Clean(ev.Name) != target {
+					continue
+				}
+				if ev.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename) != 0 {
+					c.Reload()
+				}
+			case _, ok := <-w.Errors:
+				if !ok {
+					return
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	cancel = func() {
+		close(done)
+		w.Close()
+	}
+	return cancel, nil
+}